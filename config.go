@@ -0,0 +1,97 @@
+package finder
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// tokenizeConfigLine splits a finders.conf line on whitespace, honoring
+// "\" as an escape for the character that follows it (so "\ " keeps a
+// literal space, "\#" keeps a literal "#", and "\\" keeps a literal
+// "\"). An unescaped "#" starts a trailing comment.
+func tokenizeConfigLine(line string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; c {
+		case '\\':
+			if i+1 >= len(line) {
+				return nil, errors.New(`trailing "\" with nothing to escape`)
+			}
+			cur.WriteByte(line[i+1])
+			i++
+		case '#':
+			flush()
+			return tokens, nil
+		case ' ', '\t':
+			flush()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+	return tokens, nil
+}
+
+// loadConfigInto parses the finders.conf at path and applies its
+// directives to r. Each non-comment, non-blank line is newline
+// terminated and is either:
+//
+//	finder <name> [default-arg ...]   register name, or override its
+//	                                   default args if already registered
+//	priority <name> [name ...]        move the listed finders to the
+//	                                   front of the lookup order, in the
+//	                                   order given
+//
+// A malformed or unrecognised line produces an error naming the
+// offending 1-based line number.
+func loadConfigInto(r *Registry, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		tokens, err := tokenizeConfigLine(scanner.Text())
+		if err != nil {
+			return errors.Wrapf(err, "%s:%d", path, lineNo)
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+
+		directive, rest := tokens[0], tokens[1:]
+		switch directive {
+		case "finder":
+			if len(rest) == 0 {
+				return errors.Errorf("%s:%d: finder directive needs a name", path, lineNo)
+			}
+			r.SetArgs(rest[0], rest[1:])
+		case "priority":
+			if len(rest) == 0 {
+				return errors.Errorf("%s:%d: priority directive needs at least one name", path, lineNo)
+			}
+			r.Reorder(rest)
+		default:
+			return errors.Errorf("%s:%d: unknown directive %q", path, lineNo, directive)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrapf(err, "%s: failed to read", path)
+	}
+	return nil
+}
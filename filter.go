@@ -0,0 +1,176 @@
+package finder
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SelectOptions configures how candidate items are filtered before being
+// handed to the underlying finder process
+type SelectOptions struct {
+	// Exclude is a list of glob patterns matched against Item.Key; any
+	// item matching one of them is dropped before the finder sees it
+	Exclude []string
+	// Include, when non-empty, keeps only items whose Key matches at
+	// least one of these glob patterns
+	Include []string
+	// ExcludeFile is a path to a file with one glob pattern per line.
+	// "#" starts a comment and "\" escapes the character that follows
+	// it, so a literal "#" or "\" can be matched with "\#" / "\\".
+	ExcludeFile string
+	// Tags, when non-empty, keeps only items carrying at least one of
+	// these tags
+	Tags []string
+}
+
+// SelectWith is the filterable variant of Select: args is first reduced
+// according to opts, and only the surviving items are piped to the
+// finder. Filtering happens here in Go, before Read, so it works the
+// same way regardless of which finder is ultimately invoked.
+func (c *Command) SelectWith(args interface{}, opts SelectOptions) ([]interface{}, error) {
+	items, err := toItems(args)
+	if err != nil {
+		return nil, err
+	}
+
+	excludes := append([]string{}, opts.Exclude...)
+	if opts.ExcludeFile != "" {
+		patterns, err := readPatternFile(opts.ExcludeFile)
+		if err != nil {
+			return nil, err
+		}
+		excludes = append(excludes, patterns...)
+	}
+
+	filtered, err := filterItems(items, excludes, opts.Include, opts.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Select(filtered)
+}
+
+// toItems normalises Select's accepted argument types down to Items
+func toItems(args interface{}) (Items, error) {
+	switch v := args.(type) {
+	case Items:
+		return v, nil
+	case []string:
+		items := NewItems()
+		for _, s := range v {
+			items.Add(s, s)
+		}
+		return items, nil
+	default:
+		return nil, errors.New("Error")
+	}
+}
+
+// filterItems drops items matching excludes, then (if includes is
+// non-empty) drops items matching none of includes, then (if tags is
+// non-empty) drops items carrying none of tags
+func filterItems(items Items, excludes, includes, tags []string) (Items, error) {
+	var result Items
+	for _, item := range items {
+		excluded, err := matchAny(excludes, item.Key)
+		if err != nil {
+			return nil, err
+		}
+		if excluded {
+			continue
+		}
+
+		if len(includes) > 0 {
+			included, err := matchAny(includes, item.Key)
+			if err != nil {
+				return nil, err
+			}
+			if !included {
+				continue
+			}
+		}
+
+		if len(tags) > 0 && !hasAnyTag(item.Tags, tags) {
+			continue
+		}
+
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func matchAny(patterns []string, key string) (bool, error) {
+	for _, p := range patterns {
+		ok, err := path.Match(p, key)
+		if err != nil {
+			return false, errors.Wrapf(err, "%s: malformed glob pattern", p)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func hasAnyTag(itemTags, want []string) bool {
+	for _, t := range itemTags {
+		for _, w := range want {
+			if t == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readPatternFile reads a glob-pattern-per-line exclude file
+func readPatternFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: failed to open exclude file", path)
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if pattern := unescapePatternLine(scanner.Text()); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "%s: failed to read exclude file", path)
+	}
+	return patterns, nil
+}
+
+// unescapePatternLine trims a pattern file line, treating an unescaped
+// "#" as the start of a comment and "\" as an escape for the character
+// that follows it (so "\#" keeps a literal "#" and "\\" keeps a literal
+// "\"). An escaped glob metacharacter ("\", "*", "?", "[") is returned
+// still escaped, so path.Match matches it literally.
+func unescapePatternLine(raw string) string {
+	line := strings.TrimSpace(raw)
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if i+1 < len(line) {
+				if strings.IndexByte(`\*?[`, line[i+1]) >= 0 {
+					b.WriteByte('\\')
+				}
+				b.WriteByte(line[i+1])
+				i++
+			}
+		case '#':
+			return strings.TrimSpace(b.String())
+		default:
+			b.WriteByte(line[i])
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
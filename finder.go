@@ -2,8 +2,11 @@ package finder
 
 import (
 	"bytes"
+	"context"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/b4b4r07/go-finder/source"
@@ -13,6 +16,7 @@ import (
 // CLI is the command having a command-line interface
 type CLI interface {
 	Run() ([]string, error)
+	RunContext(context.Context) ([]string, error)
 	Read(source.Source)
 }
 
@@ -20,6 +24,7 @@ type CLI interface {
 type Item struct {
 	Key   string
 	Value interface{}
+	Tags  []string
 }
 
 // Items is the collection of Item
@@ -38,11 +43,22 @@ func (i *Items) Add(key string, value interface{}) {
 	})
 }
 
+// AddTagged adds an item to Items along with the facet tags it carries,
+// which SelectWith's Tags filter can later match against
+func (i *Items) AddTagged(key string, value interface{}, tags ...string) {
+	*i = append(*i, Item{
+		Key:   key,
+		Value: value,
+		Tags:  tags,
+	})
+}
+
 // Finder is the interface of a filter command
 type Finder interface {
 	CLI
 	Install(string) error
 	Select(interface{}) ([]interface{}, error)
+	SelectContext(context.Context, interface{}) ([]interface{}, error)
 }
 
 // Command represents the command
@@ -52,6 +68,12 @@ type Command struct {
 	Path   string
 	Items  Items
 	Source source.Source
+	// UseShell makes RunContext invoke the finder through $SHELL -c
+	// instead of exec'ing c.Path directly. It defaults to false: direct
+	// exec avoids a layer of shell quoting that previously made
+	// anything touching Args (like a preview command) vulnerable to
+	// injection.
+	UseShell bool
 }
 
 // Commands represents the command list
@@ -90,13 +112,26 @@ func (c Commands) Lookup() (Command, error) {
 
 // Run runs as a command
 func (c *Command) Run() ([]string, error) {
-	shell := os.Getenv("SHELL")
-	if len(shell) == 0 {
-		shell = "sh"
+	return c.RunContext(context.Background())
+}
+
+// RunContext runs as a command, killing the underlying process and
+// unblocking the source goroutine as soon as ctx is done. This lets
+// callers enforce a deadline (context.WithTimeout) or cancel a hung
+// finder on server shutdown.
+func (c *Command) RunContext(ctx context.Context) ([]string, error) {
+	var cmd *exec.Cmd
+	if c.UseShell {
+		shell := os.Getenv("SHELL")
+		if len(shell) == 0 {
+			shell = "sh"
+		}
+		cmd = exec.CommandContext(ctx, shell, "-c", c.Path+" "+strings.Join(c.Args, " "))
+	} else {
+		cmd = exec.CommandContext(ctx, c.Path, c.Args...)
 	}
 
 	var stdout bytes.Buffer
-	cmd := exec.Command(shell, "-c", c.Path+" "+strings.Join(c.Args, " "))
 	cmd.Stdout = &stdout
 	cmd.Stderr = os.Stderr
 
@@ -115,12 +150,22 @@ func (c *Command) Run() ([]string, error) {
 		return []string{}, err
 	}
 
-	err := <-errCh
-	if err != nil {
-		return []string{}, err
+	select {
+	case err := <-errCh:
+		if err != nil {
+			_ = cmd.Wait()
+			return []string{}, err
+		}
+	case <-ctx.Done():
+		in.Close()
+		_ = cmd.Wait()
+		return []string{}, ctx.Err()
 	}
 
 	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return []string{}, ctx.Err()
+		}
 		return []string{}, err
 	}
 
@@ -130,6 +175,13 @@ func (c *Command) Run() ([]string, error) {
 
 // Select selects the keys in various map
 func (c *Command) Select(args interface{}) ([]interface{}, error) {
+	return c.SelectContext(context.Background(), args)
+}
+
+// SelectContext is the context-aware variant of Select. The passed ctx
+// governs the underlying finder process, so it can be cancelled or given
+// a deadline by the caller.
+func (c *Command) SelectContext(ctx context.Context, args interface{}) ([]interface{}, error) {
 	switch items := args.(type) {
 	case Items:
 		var keys []string
@@ -140,7 +192,7 @@ func (c *Command) Select(args interface{}) ([]interface{}, error) {
 			return nil, errors.New("no items")
 		}
 		c.Read(source.Slice(keys))
-		selectedKeys, err := c.Run()
+		selectedKeys, err := c.RunContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -158,7 +210,7 @@ func (c *Command) Select(args interface{}) ([]interface{}, error) {
 			return nil, errors.New("no items")
 		}
 		c.Read(source.Slice(items))
-		selectedItems, err := c.Run()
+		selectedItems, err := c.RunContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -201,7 +253,7 @@ func New(args ...string) (Finder, error) {
 		err     error
 	)
 	if len(args) == 0 {
-		command, err = DefaultCommands.Lookup()
+		command, err = DefaultCommands.LookupFromRegistry()
 		if err != nil {
 			return nil, err
 		}
@@ -218,14 +270,67 @@ func New(args ...string) (Finder, error) {
 			Source: source.Stdin(),
 		}
 	}
-	switch command.Name {
-	case "fzf":
-		return Fzf{&command}, nil
-	case "fzy":
-		return Fzy{&command}, nil
-	case "peco":
-		return Peco{&command}, nil
-	default:
-		return &command, nil
+	return wrapCommand(command), nil
+}
+
+// wrapCommand returns the Finder implementation appropriate for
+// command.Name: whatever DefaultRegistry has on file for it (which
+// covers both the built-ins and anything declared in finders.conf), or
+// the generic *Command for finders with no command-specific behaviour
+func wrapCommand(command Command) Finder {
+	if factory, ok := DefaultRegistry.Factory(command.Name); ok {
+		return factory(&command)
+	}
+	return &command
+}
+
+// NewWithInstall behaves like New, but when none of the requested
+// finders can be found on PATH, it installs the binary via Finder.Install
+// instead of failing outright. It is opt-in (rather than New's default
+// behaviour) so existing callers don't get a surprise network call the
+// first time a finder is missing.
+func NewWithInstall(args ...string) (Finder, error) {
+	if f, err := New(args...); err == nil {
+		return f, nil
+	}
+
+	candidates := []Command{}
+	if len(args) > 0 {
+		candidates = append(candidates, Command{Name: args[0], Args: args[1:]})
+	} else {
+		candidates = append(candidates, DefaultCommands...)
+	}
+
+	var lastErr error
+	for _, c := range candidates {
+		dir, err := ioutil.TempDir("", "go-finder")
+		if err != nil {
+			lastErr = errors.Wrap(err, "failed to create install directory")
+			continue
+		}
+		binPath := filepath.Join(dir, c.Name)
+
+		finder := wrapCommand(Command{Name: c.Name, Args: c.Args})
+		if err := finder.Install(binPath); err != nil {
+			lastErr = errors.Wrapf(err, "%s: install failed", c.Name)
+			continue
+		}
+		if _, err := os.Stat(binPath); err != nil {
+			lastErr = errors.Wrapf(err, "%s: install produced nothing", c.Name)
+			continue
+		}
+
+		command := Command{
+			Name:   c.Name,
+			Args:   c.Args,
+			Path:   binPath,
+			Items:  Items{},
+			Source: source.Stdin(),
+		}
+		return wrapCommand(command), nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no available finder command")
 	}
+	return nil, lastErr
 }
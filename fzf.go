@@ -0,0 +1,45 @@
+package finder
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Fzf wraps Command with fzf-specific behaviour
+// https://github.com/junegunn/fzf
+type Fzf struct {
+	*Command
+}
+
+// Install downloads the latest fzf release for the current GOOS/GOARCH
+// from GitHub, verifies it against fzf's published checksums file, and
+// extracts the fzf binary to path
+func (f Fzf) Install(path string) error {
+	release, err := latestRelease("junegunn/fzf")
+	if err != nil {
+		return err
+	}
+	version := strings.TrimPrefix(release.TagName, "v")
+
+	asset, ok := findAsset(release.Assets, runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return errors.Errorf("fzf: no release asset for %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+
+	archivePath, err := downloadAsset(asset.BrowserDownloadURL)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archivePath)
+
+	checksumURL := fmt.Sprintf("https://github.com/junegunn/fzf/releases/download/%s/fzf_%s_checksums.txt", release.TagName, version)
+	if err := verifyChecksum(archivePath, checksumURL, asset.Name); err != nil {
+		return err
+	}
+
+	return extractBinary(archivePath, asset.Name, "fzf", path)
+}
@@ -0,0 +1,37 @@
+package finder
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// Fzy wraps Command with fzy-specific behaviour
+// https://github.com/jhawthorn/fzy
+type Fzy struct {
+	*Command
+}
+
+// Install downloads the latest fzy release for the current GOOS/GOARCH
+// from GitHub and extracts the fzy binary to path. fzy doesn't publish a
+// checksums file, so verification is skipped.
+func (f Fzy) Install(path string) error {
+	release, err := latestRelease("jhawthorn/fzy")
+	if err != nil {
+		return err
+	}
+
+	asset, ok := findAsset(release.Assets, runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return errors.Errorf("fzy: no release asset for %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+
+	archivePath, err := downloadAsset(asset.BrowserDownloadURL)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archivePath)
+
+	return extractBinary(archivePath, asset.Name, "fzy", path)
+}
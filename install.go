@@ -0,0 +1,212 @@
+package finder
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const githubAPI = "https://api.github.com"
+
+// ghAsset is a single downloadable artifact of a GitHub release
+type ghAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// ghRelease is the subset of the GitHub releases API response needed to
+// resolve the latest binary for the current platform
+type ghRelease struct {
+	TagName string    `json:"tag_name"`
+	Assets  []ghAsset `json:"assets"`
+}
+
+// latestRelease fetches the latest release metadata for owner/repo from
+// the GitHub releases API
+func latestRelease(repo string) (ghRelease, error) {
+	var release ghRelease
+	resp, err := http.Get(fmt.Sprintf("%s/repos/%s/releases/latest", githubAPI, repo))
+	if err != nil {
+		return release, errors.Wrapf(err, "%s: failed to fetch latest release", repo)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return release, errors.Errorf("%s: unexpected status fetching latest release: %s", repo, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return release, errors.Wrapf(err, "%s: failed to decode release metadata", repo)
+	}
+	return release, nil
+}
+
+// findAsset returns the first asset whose (lowercased) name contains all
+// of substrs, which is typically enough to disambiguate GOOS/GOARCH
+func findAsset(assets []ghAsset, substrs ...string) (ghAsset, bool) {
+	for _, a := range assets {
+		name := strings.ToLower(a.Name)
+		matched := true
+		for _, s := range substrs {
+			if !strings.Contains(name, strings.ToLower(s)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return a, true
+		}
+	}
+	return ghAsset{}, false
+}
+
+// downloadAsset downloads url's body into a temp file and returns its path
+func downloadAsset(url string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", errors.Wrapf(err, "%s: failed to download asset", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("%s: unexpected status downloading asset: %s", url, resp.Status)
+	}
+	f, err := ioutil.TempFile("", "go-finder-asset-*")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create temp file")
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return "", errors.Wrap(err, "failed to write downloaded asset")
+	}
+	return f.Name(), nil
+}
+
+// verifyChecksum downloads checksumURL, a sha256sum-style file with one
+// "<hex>  <filename>" entry per line, and checks that file matches the
+// entry for name. A missing entry is treated as "nothing to verify"
+// rather than an error, since not every finder publishes checksums.
+func verifyChecksum(file, checksumURL, name string) error {
+	resp, err := http.Get(checksumURL)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to download checksums", checksumURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read checksums")
+	}
+
+	var want string
+	for _, line := range strings.Split(string(body), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.Contains(fields[1], name) {
+			want = fields[0]
+			break
+		}
+	}
+	if want == "" {
+		return nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.Wrap(err, "failed to open downloaded asset")
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return errors.Wrap(err, "failed to hash downloaded asset")
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return errors.Errorf("%s: checksum mismatch: want %s, got %s", name, want, got)
+	}
+	return nil
+}
+
+// extractBinary extracts binName from the tar.gz or zip archive at
+// archivePath and writes it to dest with mode 0755. The archive type is
+// decided from assetName (the asset's original name as published in the
+// release, e.g. "fzf-0.46.0-windows_amd64.zip"), not archivePath, since
+// archivePath is a randomly-suffixed temp file with no extension of its
+// own.
+func extractBinary(archivePath, assetName, binName, dest string) error {
+	if strings.HasSuffix(assetName, ".zip") {
+		return extractFromZip(archivePath, binName, dest)
+	}
+	return extractFromTarGz(archivePath, binName, dest)
+}
+
+func extractFromTarGz(archivePath, binName, dest string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.Wrap(err, "failed to open gzip stream")
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read tar entry")
+		}
+		if path.Base(hdr.Name) != binName {
+			continue
+		}
+		return writeExecutable(dest, tr)
+	}
+	return errors.Errorf("%s: not found in archive", binName)
+}
+
+func extractFromZip(archivePath, binName, dest string) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to open zip archive")
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		if path.Base(zf.Name) != binName {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return errors.Wrap(err, "failed to open zip entry")
+		}
+		defer rc.Close()
+		return writeExecutable(dest, rc)
+	}
+	return errors.Errorf("%s: not found in archive", binName)
+}
+
+func writeExecutable(dest string, r io.Reader) error {
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to create", dest)
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, r); err != nil {
+		return errors.Wrapf(err, "%s: failed to write", dest)
+	}
+	return nil
+}
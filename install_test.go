@@ -0,0 +1,52 @@
+package finder
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// extractBinary must decide tar.gz vs zip from the asset's published
+// name, not from the (randomly-suffixed, extension-less) temp file path
+// downloadAsset hands it.
+func TestExtractBinaryUsesAssetNameNotTempPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-finder-install-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archivePath := filepath.Join(dir, "download")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("fzf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("#!/bin/sh\necho fzf\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(dir, "fzf")
+	if err := extractBinary(archivePath, "fzf-0.46.0-windows_amd64.zip", "fzf", dest); err != nil {
+		t.Fatalf("extractBinary: %v", err)
+	}
+
+	got, err := ioutil.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "#!/bin/sh\necho fzf\n" {
+		t.Fatalf("extracted binary content mismatch: %q", got)
+	}
+}
@@ -0,0 +1,45 @@
+package finder
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Peco wraps Command with peco-specific behaviour
+// https://github.com/peco/peco
+type Peco struct {
+	*Command
+}
+
+// Install downloads the latest peco release for the current GOOS/GOARCH
+// from GitHub, verifies it against peco's published checksums file, and
+// extracts the peco binary to path
+func (p Peco) Install(path string) error {
+	release, err := latestRelease("peco/peco")
+	if err != nil {
+		return err
+	}
+	version := strings.TrimPrefix(release.TagName, "v")
+
+	asset, ok := findAsset(release.Assets, runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return errors.Errorf("peco: no release asset for %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+
+	archivePath, err := downloadAsset(asset.BrowserDownloadURL)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archivePath)
+
+	checksumURL := fmt.Sprintf("https://github.com/peco/peco/releases/download/%s/peco_%s_checksums.txt", release.TagName, version)
+	if err := verifyChecksum(archivePath, checksumURL, asset.Name); err != nil {
+		return err
+	}
+
+	return extractBinary(archivePath, asset.Name, "peco", path)
+}
@@ -0,0 +1,28 @@
+package finder
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+// Percol wraps Command with percol-specific behaviour
+// https://github.com/mooz/percol
+type Percol struct {
+	*Command
+}
+
+// Install installs percol via pip, since percol ships as a Python
+// package rather than a standalone binary. Unlike the other finders'
+// Install, path is treated as pip's --target directory rather than a
+// single executable file.
+func (p Percol) Install(path string) error {
+	cmd := exec.Command("pip", "install", "--target", path, "percol")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "percol: pip install failed")
+	}
+	return nil
+}
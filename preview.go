@@ -0,0 +1,51 @@
+package finder
+
+import "strings"
+
+// PreviewOptions tunes how Command.Preview's template is wired into the
+// underlying finder's command line
+type PreviewOptions struct {
+	// Window, when set, is passed straight through as fzf's
+	// --preview-window value (e.g. "right:60%")
+	Window string
+}
+
+// Preview attaches a live preview pane that runs cmd whenever the
+// candidate under the cursor changes. cmd is a template using fzf-style
+// {} / {1} / {2..} placeholders, which the finder itself resolves
+// against the tab-separated fields of the highlighted line at preview
+// time.
+//
+// By default (UseShell is false) RunContext execs c.Path directly, so
+// cmd is passed to the finder as its own, single argv element; the
+// finder is what shells out to run it, so no quoting is needed or
+// wanted here — shellQuote-ing it would hand the finder a literal
+// "'cmd'" string, which its own `sh -c` would fail to parse. Only when
+// c.UseShell is true does RunContext re-join Args and re-parse them
+// through sh -c itself, at which point cmd needs escaping to survive as
+// one argument.
+func (c *Command) Preview(cmd string, opts PreviewOptions) {
+	template := cmd
+	if c.UseShell {
+		template = shellQuote(cmd)
+	}
+	switch c.Name {
+	case "fzf":
+		c.Args = append(c.Args, "--preview", template)
+		if opts.Window != "" {
+			c.Args = append(c.Args, "--preview-window", opts.Window)
+		}
+	default:
+		// peco configures custom actions through its config file, not a
+		// CLI flag, and has no equivalent for percol either; emitting an
+		// invented flag would just make the finder reject the whole
+		// invocation, so finders without a known preview mechanism get
+		// a no-op here.
+	}
+}
+
+// shellQuote single-quotes s for safe use as one argument of a POSIX
+// shell command line, escaping any embedded single quotes
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
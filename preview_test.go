@@ -0,0 +1,42 @@
+package finder
+
+import "testing"
+
+func TestCommandPreviewDirectExec(t *testing.T) {
+	c := &Command{Name: "fzf"}
+	c.Preview("cat {}", PreviewOptions{})
+
+	want := []string{"--preview", "cat {}"}
+	if len(c.Args) != len(want) {
+		t.Fatalf("got args %v, want %v", c.Args, want)
+	}
+	for i := range want {
+		if c.Args[i] != want[i] {
+			t.Fatalf("got args %v, want %v", c.Args, want)
+		}
+	}
+}
+
+func TestCommandPreviewUseShell(t *testing.T) {
+	c := &Command{Name: "fzf", UseShell: true}
+	c.Preview("cat {}", PreviewOptions{})
+
+	want := []string{"--preview", "'cat {}'"}
+	if len(c.Args) != len(want) {
+		t.Fatalf("got args %v, want %v", c.Args, want)
+	}
+	for i := range want {
+		if c.Args[i] != want[i] {
+			t.Fatalf("got args %v, want %v", c.Args, want)
+		}
+	}
+}
+
+func TestCommandPreviewPecoIsNoop(t *testing.T) {
+	c := &Command{Name: "peco"}
+	c.Preview("cat {}", PreviewOptions{})
+
+	if len(c.Args) != 0 {
+		t.Fatalf("peco has no preview flag; expected Args untouched, got %v", c.Args)
+	}
+}
@@ -0,0 +1,157 @@
+package finder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// regEntry is one finder known to a Registry, either built in via
+// Register or declared by a user's finders.conf
+type regEntry struct {
+	name        string
+	factory     func(*Command) Finder
+	defaultArgs []string
+}
+
+// Registry holds the finders New consults, in lookup priority order.
+// Register appends a new entry or, if name is already known, replaces
+// its factory and default args in place so priority is preserved.
+type Registry struct {
+	entries []regEntry
+	byName  map[string]int
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{byName: map[string]int{}}
+}
+
+// Register adds name to the registry with the given factory and default
+// args, or replaces an existing entry of the same name in place
+func (r *Registry) Register(name string, factory func(*Command) Finder, defaultArgs []string) {
+	entry := regEntry{name: name, factory: factory, defaultArgs: defaultArgs}
+	if i, ok := r.byName[name]; ok {
+		r.entries[i] = entry
+		return
+	}
+	r.byName[name] = len(r.entries)
+	r.entries = append(r.entries, entry)
+}
+
+// MustRegister is Register, but panics if name is already registered.
+// Meant for package init, where a duplicate registration is a
+// programming error rather than something to recover from.
+func (r *Registry) MustRegister(name string, factory func(*Command) Finder, defaultArgs []string) {
+	if _, ok := r.byName[name]; ok {
+		panic(fmt.Sprintf("finder: %s already registered", name))
+	}
+	r.Register(name, factory, defaultArgs)
+}
+
+// SetArgs overrides name's default args without touching its factory,
+// registering name with a generic *Command factory if it isn't known
+// yet. This is what finders.conf's "finder" directive uses, so
+// overriding fzf's args doesn't demote it to a plain Command and lose
+// Fzf's Install.
+func (r *Registry) SetArgs(name string, args []string) {
+	if i, ok := r.byName[name]; ok {
+		r.entries[i].defaultArgs = args
+		return
+	}
+	r.Register(name, func(c *Command) Finder { return c }, args)
+}
+
+// Reorder moves the listed names to the front of the registry's lookup
+// order, in the order given. Entries not listed keep their existing
+// relative order after them; unknown names are ignored.
+func (r *Registry) Reorder(names []string) {
+	var front []regEntry
+	used := map[string]bool{}
+	for _, name := range names {
+		if i, ok := r.byName[name]; ok && !used[name] {
+			front = append(front, r.entries[i])
+			used[name] = true
+		}
+	}
+	var rest []regEntry
+	for _, e := range r.entries {
+		if !used[e.name] {
+			rest = append(rest, e)
+		}
+	}
+	r.entries = append(front, rest...)
+	r.byName = map[string]int{}
+	for i, e := range r.entries {
+		r.byName[e.name] = i
+	}
+}
+
+// Commands returns the registry's entries as a Commands list, in lookup
+// priority order
+func (r *Registry) Commands() Commands {
+	cmds := make(Commands, len(r.entries))
+	for i, e := range r.entries {
+		cmds[i] = Command{Name: e.name, Args: e.defaultArgs}
+	}
+	return cmds
+}
+
+// Factory returns the registered factory for name, if any
+func (r *Registry) Factory(name string) (func(*Command) Finder, bool) {
+	i, ok := r.byName[name]
+	if !ok {
+		return nil, false
+	}
+	return r.entries[i].factory, true
+}
+
+// DefaultRegistry is the package-wide Registry consulted by
+// Commands.LookupFromRegistry. It starts out mirroring DefaultCommands,
+// then is extended or overridden by an optional user config file.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	DefaultRegistry.Register("fzf", func(c *Command) Finder { return Fzf{c} }, []string{"--reverse", "--height=50%", "--ansi", "--multi"})
+	DefaultRegistry.Register("fzy", func(c *Command) Finder { return Fzy{c} }, nil)
+	DefaultRegistry.Register("peco", func(c *Command) Finder { return Peco{c} }, nil)
+	DefaultRegistry.Register("percol", func(c *Command) Finder { return Percol{c} }, nil)
+
+	path, err := configPath()
+	if err != nil {
+		return
+	}
+	if err := loadConfigInto(DefaultRegistry, path); err != nil {
+		// A missing config file is the common case and isn't worth a
+		// word; anything else (a typo in finders.conf) is reported but
+		// not fatal, since New callers shouldn't fail just because the
+		// user's config has a mistake in it.
+		if !os.IsNotExist(errors.Cause(err)) {
+			fmt.Fprintln(os.Stderr, "go-finder: "+err.Error())
+		}
+	}
+}
+
+// configPath resolves $XDG_CONFIG_HOME/go-finder/finders.conf, falling
+// back to $HOME/.config/go-finder/finders.conf
+func configPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "go-finder", "finders.conf"), nil
+}
+
+// LookupFromRegistry behaves like Lookup, but consults DefaultRegistry
+// (built-ins plus anything declared in the user's finders.conf) instead
+// of c, so New() can pick up user-registered finders and reordered
+// priorities without anyone touching DefaultCommands.
+func (c Commands) LookupFromRegistry() (Command, error) {
+	return DefaultRegistry.Commands().Lookup()
+}
@@ -0,0 +1,110 @@
+package finder
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTokenizeConfigLine(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"plain", "fzf --reverse --multi", []string{"fzf", "--reverse", "--multi"}, false},
+		{"comment", "fzf --reverse # use reverse layout", []string{"fzf", "--reverse"}, false},
+		{"escaped hash", `fzf --preview=\#files`, []string{"fzf", "--preview=#files"}, false},
+		{"escaped space", `fzf --preview=a\ b`, []string{"fzf", "--preview=a b"}, false},
+		{"escaped backslash", `fzf a\\b`, []string{"fzf", `a\b`}, false},
+		{"trailing backslash", `fzf a\`, nil, true},
+		{"blank", "   ", nil, false},
+		{"only comment", "# nothing here", nil, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tokenizeConfigLine(tc.in)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got tokens %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(got, "\x00") != strings.Join(tc.want, "\x00") {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigIntoPrecedence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-finder-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "finders.conf")
+	contents := strings.Join([]string{
+		"# declare a brand new finder",
+		"finder myfinder --alpha",
+		"# override an existing one's args",
+		"finder fzf --border",
+		"priority myfinder fzf",
+	}, "\n")
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry()
+	r.Register("fzf", func(c *Command) Finder { return Fzf{c} }, []string{"--reverse"})
+	r.Register("peco", func(c *Command) Finder { return Peco{c} }, nil)
+
+	if err := loadConfigInto(r, path); err != nil {
+		t.Fatalf("loadConfigInto: %v", err)
+	}
+
+	cmds := r.Commands()
+	if len(cmds) != 3 {
+		t.Fatalf("expected 3 registered finders, got %d: %v", len(cmds), cmds)
+	}
+	if cmds[0].Name != "myfinder" || cmds[1].Name != "fzf" {
+		t.Fatalf("priority directive not honoured, got order %v", cmds)
+	}
+	for _, c := range cmds {
+		if c.Name == "fzf" && strings.Join(c.Args, " ") != "--border" {
+			t.Fatalf("finder directive did not override default args: %v", c.Args)
+		}
+	}
+	factory, ok := r.Factory("fzf")
+	if !ok {
+		t.Fatal("fzf factory should survive an args-only override")
+	}
+	if _, ok := factory(&Command{}).(Fzf); !ok {
+		t.Fatal("fzf factory should still build an Fzf, not a generic Command")
+	}
+}
+
+func TestLoadConfigIntoMalformedLine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-finder-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "finders.conf")
+	contents := "finder fzf --reverse\nbogus directive\n"
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry()
+	err = loadConfigInto(r, path)
+	if err == nil {
+		t.Fatal("expected an error for the unknown directive")
+	}
+	if !strings.Contains(err.Error(), ":2:") {
+		t.Fatalf("error should report the offending line number, got: %v", err)
+	}
+}